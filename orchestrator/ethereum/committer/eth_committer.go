@@ -4,6 +4,7 @@ import (
 	"context"
 	"math/big"
 	"strings"
+	"sync"
 
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
@@ -41,10 +42,27 @@ func NewEthCommitter(
 		return nil, err
 	}
 
-	committer.nonceCache.Sync(fromAddress, func() (uint64, error) {
-		nonce, err := evmProvider.PendingNonceAt(context.TODO(), fromAddress)
-		return nonce, err
-	})
+	if committer.committerOpts.NonceJournalPath != "" {
+		journal, seed, err := openAndReplayNonceJournal(
+			context.TODO(), committer.logger, evmProvider, fromAddress, committer.committerOpts.NonceJournalPath,
+		)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to replay nonce journal")
+		}
+
+		committer.nonceJournal = journal
+		committer.nonceCache.Sync(fromAddress, func() (uint64, error) { return seed, nil })
+	} else {
+		committer.nonceCache.Sync(fromAddress, func() (uint64, error) {
+			nonce, err := evmProvider.PendingNonceAt(context.TODO(), fromAddress)
+			return nonce, err
+		})
+	}
+
+	seedNonce, _ := committer.nonceCache.Get(fromAddress)
+	committer.nonceSlotter = newNonceSlotter(uint64(seedNonce))
+
+	committer.startJournalReaper(context.TODO())
 
 	return committer, nil
 }
@@ -60,6 +78,20 @@ type ethCommitter struct {
 	ethGasLimitAdjustment float64
 	evmProvider           provider.EVMProviderWithRet
 	nonceCache            util.NonceCache
+
+	// nonceSlotter reserves nonces for SendTx without holding a lock across
+	// the RPC round trip, so submissions can be pipelined across goroutines.
+	nonceSlotter *nonceSlotter
+	resyncMu     sync.Mutex
+
+	// nonceJournal durably records in-flight transactions so a restart can
+	// recover them; nil unless WithNonceJournal was given.
+	nonceJournal *util.NonceJournal
+
+	// journalReaperCancel/journalReaperDone control the background goroutine
+	// started by startJournalReaper; both stay nil if there is no journal.
+	journalReaperCancel context.CancelFunc
+	journalReaperDone   chan struct{}
 }
 
 func (e *ethCommitter) FromAddress() ethcmn.Address {
@@ -70,6 +102,17 @@ func (e *ethCommitter) Provider() provider.EVMProvider {
 	return e.evmProvider
 }
 
+// gasOracle returns the configured GasOracle, falling back to the
+// SuggestGasPrice*adjustment strategy ethCommitter has always used when no
+// WithGasOracle option was given.
+func (e *ethCommitter) gasOracle() GasOracle {
+	if e.committerOpts.GasOracle != nil {
+		return e.committerOpts.GasOracle
+	}
+
+	return NewAdjustedSuggestedGasOracle(e.evmProvider, e.ethGasPriceAdjustment)
+}
+
 func (e *ethCommitter) EstimateGas(
 	ctx context.Context,
 	recipient ethcmn.Address,
@@ -84,20 +127,11 @@ func (e *ethCommitter) EstimateGas(
 		Context:  ctx, // with RPC timeout
 	}
 
-	suggestedGasPrice, err := e.evmProvider.SuggestGasPrice(opts.Context)
+	gasPrice, err = e.gasOracle().SuggestGasPrice(opts.Context)
 	if err != nil {
-		return 0, nil, errors.Errorf("failed to suggest gas price: %v", err)
+		return 0, nil, err
 	}
 
-	// Suggested gas price may not be accurate, so we multiply the result by the gas price adjustment factor.
-	incrementedPrice := big.NewFloat(0).Mul(
-		new(big.Float).SetInt(suggestedGasPrice),
-		big.NewFloat(e.ethGasPriceAdjustment),
-	)
-
-	gasPrice = new(big.Int)
-	incrementedPrice.Int(gasPrice)
-
 	opts.GasPrice = gasPrice
 	msg := ethereum.CallMsg{From: opts.From, To: &recipient, GasPrice: gasPrice, Value: nil, Data: txData}
 
@@ -109,6 +143,12 @@ func (e *ethCommitter) EstimateGas(
 	return gasCost, gasPrice, err
 }
 
+// SendTx signs and broadcasts a legacy transaction. The nonce is reserved
+// from nonceSlotter up front and the lock is released before the RPC call,
+// so unlike the old nonceCache.Serialize-guarded implementation, concurrent
+// callers can have submissions in flight to the node at the same time; this
+// is what lets the batch-requester and valset-relayer loops pipeline sends
+// instead of serializing every one behind a single mutex.
 func (e *ethCommitter) SendTx(
 	ctx context.Context,
 	recipient ethcmn.Address,
@@ -116,105 +156,116 @@ func (e *ethCommitter) SendTx(
 	gasCost uint64,
 	gasPrice *big.Int,
 ) (txHash ethcmn.Hash, err error) {
+	_, txHash, err = e.sendTxReserved(ctx, recipient, txData, gasCost, gasPrice)
+	return txHash, err
+}
+
+// sendTxReserved is the guts of SendTx, additionally returning the nonce the
+// transaction was (eventually) broadcast with. TxTracker uses this directly
+// so it can track the tx by nonce without having to peek at internal state.
+func (e *ethCommitter) sendTxReserved(
+	ctx context.Context,
+	recipient ethcmn.Address,
+	txData []byte,
+	gasCost uint64,
+	gasPrice *big.Int,
+) (nonce uint64, txHash ethcmn.Hash, err error) {
+	if revertErr := e.simulate(ctx, recipient, txData, gasCost, gasPrice); revertErr != nil {
+		return 0, ethcmn.Hash{}, revertErr
+	}
+
 	opts := &bind.TransactOpts{
 		From:   e.fromAddress,
 		Signer: e.fromSigner,
 
 		GasPrice: gasPrice,
 		GasLimit: gasCost,
-		Context:  ctx, // with RPC timeout
 	}
 
-	resyncNonces := func(from ethcmn.Address) {
-		e.nonceCache.Sync(from, func() (uint64, error) {
-			nonce, err := e.evmProvider.PendingNonceAt(context.TODO(), from)
-			if err != nil {
-				e.logger.Err(err).Msg("unable to acquire nonce")
-			}
+	nonce = e.nonceSlotter.Reserve()
 
-			return nonce, err
-		})
-	}
+	for {
+		opts.Nonce = new(big.Int).SetUint64(nonce)
+		var cancel context.CancelFunc
+		opts.Context, cancel = context.WithTimeout(ctx, e.committerOpts.RPCTimeout)
+		defer cancel()
+
+		tx := types.NewTransaction(opts.Nonce.Uint64(), recipient, nil, opts.GasLimit, opts.GasPrice, txData)
+		signedTx, err := opts.Signer(opts.From, tx)
+		if err != nil {
+			e.nonceSlotter.Release(nonce)
+			return nonce, ethcmn.Hash{}, errors.Wrap(err, "failed to sign transaction")
+		}
+
+		txHash = signedTx.Hash()
 
-	if err := e.nonceCache.Serialize(e.fromAddress, func() (err error) {
-		nonce, _ := e.nonceCache.Get(e.fromAddress)
-		var resyncUsed bool
-
-		for {
-			opts.Nonce = big.NewInt(nonce)
-			var cancel context.CancelFunc
-			opts.Context, cancel = context.WithTimeout(ctx, e.committerOpts.RPCTimeout)
-			defer cancel()
-
-			tx := types.NewTransaction(opts.Nonce.Uint64(), recipient, nil, opts.GasLimit, opts.GasPrice, txData)
-			signedTx, err := opts.Signer(opts.From, tx)
-			if err != nil {
-				err := errors.Wrap(err, "failed to sign transaction")
-				return err
+		txHashRet, sendErr := e.evmProvider.SendTransactionWithRet(opts.Context, signedTx)
+		if sendErr == nil {
+			if rawTx, encErr := signedTx.MarshalBinary(); encErr == nil {
+				recordSentTx(e.nonceJournal, e.fromAddress, nonce, txHashRet, rawTx)
 			}
+			// override with a real hash from node resp
+			return nonce, txHashRet, nil
+		}
+
+		e.logger.Err(sendErr).
+			Str("tx_hash", txHash.Hex()).
+			Str("tx_hash_ret", txHashRet.Hex()).
+			Msg("sendTransaction failed")
+
+		switch {
+		case strings.Contains(sendErr.Error(), "invalid sender"):
+			e.nonceSlotter.Release(nonce)
+			return nonce, ethcmn.Hash{}, errors.New("failed to sign transaction")
 
-			txHash = signedTx.Hash()
+		case strings.Contains(sendErr.Error(), "nonce too low"),
+			strings.Contains(sendErr.Error(), "nonce too high"),
+			strings.Contains(sendErr.Error(), "the tx doesn't have the correct nonce"):
 
-			txHashRet, err := e.evmProvider.SendTransactionWithRet(opts.Context, signedTx)
-			if err == nil {
-				// override with a real hash from node resp
-				txHash = txHashRet
-				e.nonceCache.Incr(e.fromAddress)
-				return nil
+			resynced, resyncErr := e.resyncAndRetry()
+			if resyncErr != nil {
+				return nonce, ethcmn.Hash{}, errors.Wrapf(sendErr, "nonce %d mismatch, resync failed: %v", nonce, resyncErr)
 			}
 
-			e.logger.Err(err).
-				Str("tx_hash", txHash.Hex()).
-				Str("tx_hash_ret", txHashRet.Hex()).
-				Msg("sendTransaction failed")
-
-			switch {
-			case strings.Contains(err.Error(), "invalid sender"):
-				err := errors.New("failed to sign transaction")
-				e.nonceCache.Incr(e.fromAddress)
-				return err
-			case strings.Contains(err.Error(), "nonce too low"),
-				strings.Contains(err.Error(), "nonce too high"),
-				strings.Contains(err.Error(), "the tx doesn't have the correct nonce"):
-
-				if resyncUsed {
-					e.logger.Error().
-						Str("from_address", e.fromAddress.Hex()).
-						Int64("nonce", nonce).
-						Msg("nonces synced, but still wrong nonce for address")
-					err = errors.Wrapf(err, "nonce %d mismatch", nonce)
-					return err
-				}
-
-				resyncNonces(e.fromAddress)
-
-				resyncUsed = true
-				// try again with updated nonce
-				nonce, _ = e.nonceCache.Get(e.fromAddress)
-				opts.Nonce = big.NewInt(nonce)
+			nonce = resynced
+			continue
 
+		default:
+			if strings.Contains(sendErr.Error(), "known transaction") {
+				// someone else already filled this nonce; reserve the next
+				// one and try again
+				nonce = e.nonceSlotter.Reserve()
 				continue
+			}
 
-			default:
-				if strings.Contains(err.Error(), "known transaction") {
-					// skip one nonce step, try to send again
-					nonce := e.nonceCache.Incr(e.fromAddress)
-					opts.Nonce = big.NewInt(nonce)
-					continue
-				}
-
-				if strings.Contains(err.Error(), "VM Exception") {
-					// a VM execution consumes gas and nonce is increasing
-					e.nonceCache.Incr(e.fromAddress)
-					return err
-				}
-
-				return err
+			if strings.Contains(sendErr.Error(), "VM Exception") {
+				// a VM execution consumes gas, so the nonce was used even
+				// though the call reverted; do not release it
+				return nonce, ethcmn.Hash{}, sendErr
 			}
+
+			e.nonceSlotter.Release(nonce)
+			return nonce, ethcmn.Hash{}, sendErr
 		}
-	}); err != nil {
-		return ethcmn.Hash{}, err
 	}
+}
+
+// resyncAndRetry re-fetches the pending nonce from the network and reseeds
+// nonceSlotter from it, returning a freshly reserved nonce. resyncMu
+// serializes concurrent resyncs but does not merge them: every caller that
+// hits a mismatch makes its own PendingNonceAt round trip and reseeds
+// nonceSlotter in turn before reserving the nonce it retries with.
+func (e *ethCommitter) resyncAndRetry() (uint64, error) {
+	e.resyncMu.Lock()
+	defer e.resyncMu.Unlock()
+
+	nonce, err := e.evmProvider.PendingNonceAt(context.TODO(), e.fromAddress)
+	if err != nil {
+		return 0, err
+	}
+
+	e.nonceSlotter.Reseed(nonce)
+	e.nonceCache.Sync(e.fromAddress, func() (uint64, error) { return nonce, nil })
 
-	return txHash, nil
+	return e.nonceSlotter.Reserve(), nil
 }
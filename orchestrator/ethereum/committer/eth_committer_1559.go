@@ -0,0 +1,183 @@
+package committer
+
+import (
+	"context"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	ethcmn "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/pkg/errors"
+)
+
+// ChainSupportsEIP1559 reports whether the connected chain has activated EIP-1559.
+// It inspects the latest block header and treats a non-nil BaseFee as the signal,
+// which lets call sites fall back to legacy gas pricing on chains such as
+// BSC or Polygon PoS that never enabled the London fork's fee market.
+//
+// NOTE: the peggy/batch/valset relayer loops this was written for do not
+// exist in this checkout (this tree only contains orchestrator/ethereum/committer),
+// so apart from EstimateGas1559's own ChainSupportsEIP1559 check, nothing in
+// this package calls ChainSupportsEIP1559/EstimateGas1559/SendTx1559. Wiring a call site in is scoped out of this change; a relayer loop
+// switching to these APIs should call ChainSupportsEIP1559 once per send to
+// decide between this file's methods and the legacy EstimateGas/SendTx.
+func (e *ethCommitter) ChainSupportsEIP1559(ctx context.Context) (bool, error) {
+	header, err := e.evmProvider.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to fetch latest header")
+	}
+
+	return header.BaseFee != nil, nil
+}
+
+// EstimateGas1559 is the EIP-1559 counterpart of EstimateGas. It takes
+// maxFeePerGas and maxPriorityFeePerGas from the committer's GasOracle. With
+// the default oracle (no WithGasOracle option), maxPriorityFeePerGas is the
+// node's suggested tip adjusted by ethGasPriceAdjustment and maxFeePerGas is
+// derived from the latest base fee, following the same "tip + 2*baseFee"
+// heuristic go-ethereum's own transactor uses.
+func (e *ethCommitter) EstimateGas1559(
+	ctx context.Context,
+	recipient ethcmn.Address,
+	txData []byte,
+) (gasCost uint64, tipCap *big.Int, feeCap *big.Int, err error) {
+	supported, err := e.ChainSupportsEIP1559(ctx)
+	if err != nil {
+		return 0, nil, nil, err
+	}
+	if !supported {
+		return 0, nil, nil, errors.New("connected chain does not support EIP-1559")
+	}
+
+	feeCap, tipCap, err = e.gasOracle().SuggestFees(ctx)
+	if err != nil {
+		return 0, nil, nil, err
+	}
+
+	msg := ethereum.CallMsg{From: e.fromAddress, To: &recipient, GasFeeCap: feeCap, GasTipCap: tipCap, Value: nil, Data: txData}
+
+	gasCost, err = e.evmProvider.EstimateGas(ctx, msg)
+	gasCost = uint64(float64(gasCost) * e.ethGasLimitAdjustment)
+
+	return gasCost, tipCap, feeCap, err
+}
+
+// SendTx1559 submits a types.DynamicFeeTx built from tipCap/feeCap as
+// returned by EstimateGas1559. It reserves its nonce from the same
+// nonceSlotter the legacy SendTx uses via sendTxReserved, so the two APIs
+// can be mixed on one committer (per chunk0-1's auto-detect/fall-back
+// design) without the two nonce allocators drifting out of sync.
+func (e *ethCommitter) SendTx1559(
+	ctx context.Context,
+	recipient ethcmn.Address,
+	txData []byte,
+	gasCost uint64,
+	tipCap *big.Int,
+	feeCap *big.Int,
+) (txHash ethcmn.Hash, err error) {
+	_, txHash, err = e.sendTx1559Reserved(ctx, recipient, txData, gasCost, tipCap, feeCap)
+	return txHash, err
+}
+
+func (e *ethCommitter) sendTx1559Reserved(
+	ctx context.Context,
+	recipient ethcmn.Address,
+	txData []byte,
+	gasCost uint64,
+	tipCap *big.Int,
+	feeCap *big.Int,
+) (nonce uint64, txHash ethcmn.Hash, err error) {
+	if revertErr := e.simulate1559(ctx, recipient, txData, gasCost, tipCap, feeCap); revertErr != nil {
+		return 0, ethcmn.Hash{}, revertErr
+	}
+
+	chainID, err := e.evmProvider.NetworkID(ctx)
+	if err != nil {
+		return 0, ethcmn.Hash{}, errors.Wrap(err, "failed to fetch chain id")
+	}
+
+	opts := &bind.TransactOpts{
+		From:      e.fromAddress,
+		Signer:    e.fromSigner,
+		GasFeeCap: feeCap,
+		GasTipCap: tipCap,
+		GasLimit:  gasCost,
+	}
+
+	nonce = e.nonceSlotter.Reserve()
+
+	for {
+		var cancel context.CancelFunc
+		opts.Context, cancel = context.WithTimeout(ctx, e.committerOpts.RPCTimeout)
+		defer cancel()
+
+		tx := types.NewTx(&types.DynamicFeeTx{
+			ChainID:   chainID,
+			Nonce:     nonce,
+			GasTipCap: opts.GasTipCap,
+			GasFeeCap: opts.GasFeeCap,
+			Gas:       opts.GasLimit,
+			To:        &recipient,
+			Data:      txData,
+		})
+
+		signedTx, err := opts.Signer(opts.From, tx)
+		if err != nil {
+			e.nonceSlotter.Release(nonce)
+			return nonce, ethcmn.Hash{}, errors.Wrap(err, "failed to sign transaction")
+		}
+
+		txHash = signedTx.Hash()
+
+		txHashRet, sendErr := e.evmProvider.SendTransactionWithRet(opts.Context, signedTx)
+		if sendErr == nil {
+			if rawTx, encErr := signedTx.MarshalBinary(); encErr == nil {
+				recordSentTx(e.nonceJournal, e.fromAddress, nonce, txHashRet, rawTx)
+			}
+			// override with a real hash from node resp
+			return nonce, txHashRet, nil
+		}
+
+		e.logger.Err(sendErr).
+			Str("tx_hash", txHash.Hex()).
+			Str("tx_hash_ret", txHashRet.Hex()).
+			Msg("sendTransaction (1559) failed")
+
+		switch {
+		case strings.Contains(sendErr.Error(), "invalid sender"):
+			e.nonceSlotter.Release(nonce)
+			return nonce, ethcmn.Hash{}, errors.New("failed to sign transaction")
+
+		case strings.Contains(sendErr.Error(), "nonce too low"),
+			strings.Contains(sendErr.Error(), "nonce too high"),
+			strings.Contains(sendErr.Error(), "the tx doesn't have the correct nonce"):
+
+			resynced, resyncErr := e.resyncAndRetry()
+			if resyncErr != nil {
+				return nonce, ethcmn.Hash{}, errors.Wrapf(sendErr, "nonce %d mismatch, resync failed: %v", nonce, resyncErr)
+			}
+
+			nonce = resynced
+			continue
+
+		default:
+			if strings.Contains(sendErr.Error(), "known transaction") {
+				// someone else already filled this nonce; reserve the next
+				// one and try again
+				nonce = e.nonceSlotter.Reserve()
+				continue
+			}
+
+			if strings.Contains(sendErr.Error(), "VM Exception") {
+				// a VM execution consumes gas, so the nonce was used even
+				// though the call reverted; do not release it
+				return nonce, ethcmn.Hash{}, sendErr
+			}
+
+			e.nonceSlotter.Release(nonce)
+			return nonce, ethcmn.Hash{}, sendErr
+		}
+	}
+}
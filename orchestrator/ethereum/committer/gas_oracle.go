@@ -0,0 +1,297 @@
+package committer
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/pkg/errors"
+	"github.com/umee-network/peggo/orchestrator/ethereum/provider"
+)
+
+// GasOracle decides the gas price (and, for EIP-1559 chains, the fee cap and
+// priority fee) ethCommitter uses when submitting a transaction. It exists so
+// that "suggested gas price may not be accurate" (the problem the original
+// SuggestGasPrice*adjustment heuristic in EstimateGas was working around)
+// can be swapped out per-deployment instead of hardcoded.
+type GasOracle interface {
+	// SuggestGasPrice returns the legacy gas price to use for SendTx.
+	SuggestGasPrice(ctx context.Context) (*big.Int, error)
+	// SuggestFees returns the maxFeePerGas and maxPriorityFeePerGas to use
+	// for SendTx1559.
+	SuggestFees(ctx context.Context) (maxFeePerGas, maxPriorityFeePerGas *big.Int, err error)
+}
+
+// WithGasOracle overrides the default SuggestGasPrice*adjustment strategy
+// with the given GasOracle.
+func WithGasOracle(oracle GasOracle) EVMCommitterOption {
+	return func(o *options) error {
+		o.GasOracle = oracle
+		return nil
+	}
+}
+
+// adjustedSuggestedGasOracle is the default oracle: it reproduces
+// ethCommitter's original behavior of taking the node's suggested gas price
+// (or tip, for 1559) and multiplying by a fixed adjustment factor.
+type adjustedSuggestedGasOracle struct {
+	provider   provider.EVMProviderWithRet
+	adjustment float64
+}
+
+// NewAdjustedSuggestedGasOracle returns the oracle ethCommitter uses when no
+// WithGasOracle option is given.
+func NewAdjustedSuggestedGasOracle(evmProvider provider.EVMProviderWithRet, adjustment float64) GasOracle {
+	return &adjustedSuggestedGasOracle{provider: evmProvider, adjustment: adjustment}
+}
+
+func (o *adjustedSuggestedGasOracle) SuggestGasPrice(ctx context.Context) (*big.Int, error) {
+	suggested, err := o.provider.SuggestGasPrice(ctx)
+	if err != nil {
+		return nil, errors.Errorf("failed to suggest gas price: %v", err)
+	}
+
+	return adjustBigInt(suggested, o.adjustment), nil
+}
+
+func (o *adjustedSuggestedGasOracle) SuggestFees(ctx context.Context) (*big.Int, *big.Int, error) {
+	header, err := o.provider.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to fetch latest header")
+	}
+
+	if header.BaseFee == nil {
+		return nil, nil, errors.New("connected chain does not support EIP-1559")
+	}
+
+	tip, err := o.provider.SuggestGasTipCap(ctx)
+	if err != nil {
+		return nil, nil, errors.Errorf("failed to suggest gas tip cap: %v", err)
+	}
+
+	tipCap := adjustBigInt(tip, o.adjustment)
+	feeCap := new(big.Int).Add(tipCap, new(big.Int).Mul(header.BaseFee, big.NewInt(2)))
+
+	return feeCap, tipCap, nil
+}
+
+func adjustBigInt(v *big.Int, adjustment float64) *big.Int {
+	adjusted := big.NewFloat(0).Mul(new(big.Float).SetInt(v), big.NewFloat(adjustment))
+	out := new(big.Int)
+	adjusted.Int(out)
+	return out
+}
+
+// defaultFeeHistoryPercentile is the reward percentile requested from
+// eth_feeHistory when NewFeeHistoryGasOracle is not given one explicitly.
+const defaultFeeHistoryPercentile = 50
+
+// feeHistoryGasOracle prices transactions from eth_feeHistory, following the
+// "look at the last N blocks' base fee and tip percentiles" approach
+// described by EIP-1559 client implementations.
+type feeHistoryGasOracle struct {
+	provider   provider.EVMProviderWithRet
+	blocks     uint64
+	percentile float64
+}
+
+// NewFeeHistoryGasOracle returns a GasOracle backed by eth_feeHistory,
+// averaging the given reward percentile (e.g. 25, 50, 75) over the last
+// blocks blocks, weighted by gasUsedRatio.
+func NewFeeHistoryGasOracle(evmProvider provider.EVMProviderWithRet, blocks uint64, percentile float64) GasOracle {
+	if percentile <= 0 {
+		percentile = defaultFeeHistoryPercentile
+	}
+
+	return &feeHistoryGasOracle{provider: evmProvider, blocks: blocks, percentile: percentile}
+}
+
+func (o *feeHistoryGasOracle) SuggestFees(ctx context.Context) (*big.Int, *big.Int, error) {
+	history, err := o.provider.FeeHistory(ctx, o.blocks, nil, []float64{o.percentile})
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "eth_feeHistory failed")
+	}
+
+	return computeFeeHistoryFees(history)
+}
+
+// computeFeeHistoryFees derives (maxFeePerGas, maxPriorityFeePerGas) from an
+// eth_feeHistory response. It is split out from SuggestFees so the weighting
+// math can be unit tested without a live (or mocked) provider.
+//
+// The priority fee is the gasUsedRatio-weighted average of each block's
+// requested reward percentile. The weighting is done entirely in big.Float:
+// gasUsedRatio entries are fractions (<=1), so for small block windows their
+// sum is routinely below 1.0, and truncating that sum to an int64 divisor
+// before dividing would make the divisor 0.
+func computeFeeHistoryFees(history *ethereum.FeeHistory) (*big.Int, *big.Int, error) {
+	if len(history.Reward) == 0 || len(history.BaseFee) == 0 {
+		return nil, nil, errors.New("eth_feeHistory returned no data")
+	}
+
+	weightedTip := new(big.Float)
+	var totalWeight float64
+	for i, rewards := range history.Reward {
+		if len(rewards) == 0 {
+			continue
+		}
+
+		weight := history.GasUsedRatio[i]
+		if weight <= 0 {
+			weight = 0.01
+		}
+
+		contribution := new(big.Float).Mul(new(big.Float).SetInt(rewards[0]), big.NewFloat(weight))
+		weightedTip.Add(weightedTip, contribution)
+		totalWeight += weight
+	}
+
+	if totalWeight == 0 {
+		return nil, nil, errors.New("eth_feeHistory returned zero gas usage for all blocks")
+	}
+
+	tipCap, _ := new(big.Float).Quo(weightedTip, big.NewFloat(totalWeight)).Int(nil)
+
+	// baseFee[len-1] is the next (unmined) block's base fee.
+	nextBaseFee := history.BaseFee[len(history.BaseFee)-1]
+	lastUsedRatio := history.GasUsedRatio[len(history.GasUsedRatio)-1]
+
+	var feeCap *big.Int
+	if lastUsedRatio > 0.5 {
+		feeCap = adjustBigInt(nextBaseFee, 1.125)
+	} else {
+		feeCap = new(big.Int).Set(nextBaseFee)
+	}
+	feeCap.Add(feeCap, tipCap)
+
+	return feeCap, tipCap, nil
+}
+
+func (o *feeHistoryGasOracle) SuggestGasPrice(ctx context.Context) (*big.Int, error) {
+	feeCap, _, err := o.SuggestFees(ctx)
+	return feeCap, err
+}
+
+// staticGasOracle clamps the node's own suggested gas price between a
+// user-specified floor and ceiling (in wei), for operators who want the
+// convenience of network-aware pricing without the risk of it drifting
+// outside a known-acceptable range.
+type staticGasOracle struct {
+	provider provider.EVMProviderWithRet
+	floor    *big.Int
+	ceiling  *big.Int
+}
+
+// NewStaticGasOracle returns a GasOracle that clamps evmProvider's suggested
+// gas price between floor and ceiling. Both bounds also apply to
+// SuggestFees, where the clamped value is used as both the fee cap and the
+// priority fee.
+func NewStaticGasOracle(evmProvider provider.EVMProviderWithRet, floor, ceiling *big.Int) GasOracle {
+	return &staticGasOracle{provider: evmProvider, floor: floor, ceiling: ceiling}
+}
+
+func (o *staticGasOracle) clamp(v *big.Int) *big.Int {
+	if o.floor != nil && v.Cmp(o.floor) < 0 {
+		return new(big.Int).Set(o.floor)
+	}
+	if o.ceiling != nil && v.Cmp(o.ceiling) > 0 {
+		return new(big.Int).Set(o.ceiling)
+	}
+	return v
+}
+
+func (o *staticGasOracle) SuggestGasPrice(ctx context.Context) (*big.Int, error) {
+	suggested, err := o.provider.SuggestGasPrice(ctx)
+	if err != nil {
+		return nil, errors.Errorf("failed to suggest gas price: %v", err)
+	}
+
+	return o.clamp(suggested), nil
+}
+
+func (o *staticGasOracle) SuggestFees(ctx context.Context) (*big.Int, *big.Int, error) {
+	price, err := o.SuggestGasPrice(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return price, price, nil
+}
+
+// externalGasOracle fetches gas prices from a third-party HTTP endpoint,
+// such as an Etherscan or Blocknative-compatible gas price API.
+type externalGasOracle struct {
+	url        string
+	httpClient *http.Client
+}
+
+type externalGasOracleResponse struct {
+	MaxFeePerGas         string `json:"maxFeePerGas"`
+	MaxPriorityFeePerGas string `json:"maxPriorityFeePerGas"`
+	GasPrice             string `json:"gasPrice"`
+}
+
+// NewExternalGasOracle returns a GasOracle that queries url for gas prices,
+// expecting a JSON body shaped like externalGasOracleResponse.
+func NewExternalGasOracle(url string) GasOracle {
+	return &externalGasOracle{
+		url:        url,
+		httpClient: &http.Client{Timeout: time.Second * 5},
+	}
+}
+
+func (o *externalGasOracle) fetch(ctx context.Context) (*externalGasOracleResponse, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, o.url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := o.httpClient.Do(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "external gas oracle request failed")
+	}
+	defer resp.Body.Close()
+
+	var out externalGasOracleResponse
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return nil, errors.Wrap(err, "failed to decode external gas oracle response")
+	}
+
+	return &out, nil
+}
+
+func (o *externalGasOracle) SuggestGasPrice(ctx context.Context) (*big.Int, error) {
+	resp, err := o.fetch(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	price, ok := new(big.Int).SetString(resp.GasPrice, 10)
+	if !ok {
+		return nil, errors.Errorf("external gas oracle returned invalid gasPrice: %q", resp.GasPrice)
+	}
+
+	return price, nil
+}
+
+func (o *externalGasOracle) SuggestFees(ctx context.Context) (*big.Int, *big.Int, error) {
+	resp, err := o.fetch(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	feeCap, ok := new(big.Int).SetString(resp.MaxFeePerGas, 10)
+	if !ok {
+		return nil, nil, errors.Errorf("external gas oracle returned invalid maxFeePerGas: %q", resp.MaxFeePerGas)
+	}
+
+	tipCap, ok := new(big.Int).SetString(resp.MaxPriorityFeePerGas, 10)
+	if !ok {
+		return nil, nil, errors.Errorf("external gas oracle returned invalid maxPriorityFeePerGas: %q", resp.MaxPriorityFeePerGas)
+	}
+
+	return feeCap, tipCap, nil
+}
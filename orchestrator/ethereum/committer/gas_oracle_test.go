@@ -0,0 +1,107 @@
+package committer
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+)
+
+func bigInts(vals ...int64) []*big.Int {
+	out := make([]*big.Int, len(vals))
+	for i, v := range vals {
+		out[i] = big.NewInt(v)
+	}
+	return out
+}
+
+func TestComputeFeeHistoryFeesLowUsageDoesNotPanic(t *testing.T) {
+	// A single lightly-used block: gasUsedRatio sums to well below 1.0, which
+	// used to truncate to a zero big.Int divisor and panic in Div.
+	history := &ethereum.FeeHistory{
+		BaseFee:      bigInts(100, 110),
+		GasUsedRatio: []float64{0.2},
+		Reward:       [][]*big.Int{bigInts(5)},
+	}
+
+	feeCap, tipCap, err := computeFeeHistoryFees(history)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if tipCap.Cmp(big.NewInt(5)) != 0 {
+		t.Fatalf("expected tip cap 5, got %s", tipCap)
+	}
+
+	// lastUsedRatio (0.2) <= 0.5, so feeCap = baseFee[1] + tipCap = 110 + 5.
+	if want := big.NewInt(115); feeCap.Cmp(want) != 0 {
+		t.Fatalf("expected fee cap %s, got %s", want, feeCap)
+	}
+}
+
+func TestComputeFeeHistoryFeesWeightsByGasUsedRatio(t *testing.T) {
+	history := &ethereum.FeeHistory{
+		BaseFee:      bigInts(100, 100, 200),
+		GasUsedRatio: []float64{0.1, 0.9},
+		Reward:       [][]*big.Int{bigInts(10), bigInts(20)},
+	}
+
+	_, tipCap, err := computeFeeHistoryFees(history)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// (10*0.1 + 20*0.9) / (0.1+0.9) = 19
+	if want := big.NewInt(19); tipCap.Cmp(want) != 0 {
+		t.Fatalf("expected weighted tip cap %s, got %s", want, tipCap)
+	}
+}
+
+func TestComputeFeeHistoryFeesBumpsBaseFeeWhenBlocksAreBusy(t *testing.T) {
+	history := &ethereum.FeeHistory{
+		BaseFee:      bigInts(1000, 2000),
+		GasUsedRatio: []float64{0.9},
+		Reward:       [][]*big.Int{bigInts(1)},
+	}
+
+	feeCap, tipCap, err := computeFeeHistoryFees(history)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// lastUsedRatio (0.9) > 0.5, so feeCap = baseFee[1]*1.125 + tipCap = 2250 + 1.
+	if want := big.NewInt(2251); feeCap.Cmp(want) != 0 {
+		t.Fatalf("expected bumped fee cap %s, got %s", want, feeCap)
+	}
+	if tipCap.Cmp(big.NewInt(1)) != 0 {
+		t.Fatalf("expected tip cap 1, got %s", tipCap)
+	}
+}
+
+func TestComputeFeeHistoryFeesNoData(t *testing.T) {
+	if _, _, err := computeFeeHistoryFees(&ethereum.FeeHistory{}); err == nil {
+		t.Fatal("expected an error for an empty fee history response")
+	}
+}
+
+func TestStaticGasOracleClampsAgainstBothBounds(t *testing.T) {
+	oracle := &staticGasOracle{
+		floor:   big.NewInt(10),
+		ceiling: big.NewInt(100),
+	}
+
+	cases := []struct {
+		in, want int64
+	}{
+		{in: 5, want: 10},
+		{in: 50, want: 50},
+		{in: 500, want: 100},
+	}
+
+	for _, c := range cases {
+		got := oracle.clamp(big.NewInt(c.in))
+		if got.Cmp(big.NewInt(c.want)) != 0 {
+			t.Fatalf("clamp(%d): got %s, want %d", c.in, got, c.want)
+		}
+	}
+}
@@ -0,0 +1,170 @@
+package committer
+
+import (
+	"context"
+	"time"
+
+	ethcmn "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+	"github.com/umee-network/peggo/orchestrator/ethereum/provider"
+	"github.com/umee-network/peggo/orchestrator/ethereum/util"
+)
+
+// WithNonceJournal turns on the durable nonce journal at path. When set,
+// NewEthCommitter replays it on startup so a restart does not lose track of
+// transactions broadcast but no longer visible in the mempool.
+func WithNonceJournal(path string) EVMCommitterOption {
+	return func(o *options) error {
+		o.NonceJournalPath = path
+		return nil
+	}
+}
+
+// openAndReplayNonceJournal opens the journal at path and, for every
+// still-unconfirmed entry belonging to fromAddress, checks whether it was
+// mined while the orchestrator was down (in which case it is reaped) or is
+// still missing from the mempool (in which case it is rebroadcast verbatim
+// from its raw signed bytes). It returns the nonce NewEthCommitter should
+// seed nonceSlotter with: max(PendingNonceAt, highest-journaled-nonce+1).
+func openAndReplayNonceJournal(
+	ctx context.Context,
+	logger zerolog.Logger,
+	evmProvider provider.EVMProviderWithRet,
+	fromAddress ethcmn.Address,
+	path string,
+) (*util.NonceJournal, uint64, error) {
+	journal, err := util.OpenNonceJournal(path)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	seed, err := evmProvider.PendingNonceAt(ctx, fromAddress)
+	if err != nil {
+		return nil, 0, errors.Wrap(err, "failed to fetch pending nonce during journal replay")
+	}
+
+	for _, entry := range journal.Unconfirmed(fromAddress) {
+		if entry.Nonce+1 > seed {
+			seed = entry.Nonce + 1
+		}
+
+		if _, err := evmProvider.TransactionReceipt(ctx, entry.TxHash); err == nil {
+			// mined while we were down; the journal no longer needs it.
+			_ = journal.Remove(fromAddress, entry.Nonce)
+			continue
+		}
+
+		if _, _, err := evmProvider.TransactionByHash(ctx, entry.TxHash); err != nil {
+			// the node no longer has it in its mempool either; rebroadcast
+			// verbatim from the journaled raw bytes.
+			var tx types.Transaction
+			if decodeErr := tx.UnmarshalBinary(entry.RawTx); decodeErr == nil {
+				if _, sendErr := evmProvider.SendTransactionWithRet(ctx, &tx); sendErr != nil {
+					// entry stays in the journal either way, so the next
+					// restart (or TxTracker, if one is wrapping this
+					// committer) will retry it; this is just for visibility.
+					logger.Err(sendErr).
+						Str("tx_hash", entry.TxHash.Hex()).
+						Uint64("nonce", entry.Nonce).
+						Msg("failed to rebroadcast journaled transaction missing from the mempool")
+				}
+			}
+		}
+	}
+
+	return journal, seed, nil
+}
+
+// recordSentTx journals a transaction sendTxReserved just broadcast
+// successfully, so it can be recovered on a future restart.
+func recordSentTx(journal *util.NonceJournal, from ethcmn.Address, nonce uint64, txHash ethcmn.Hash, rawTx []byte) {
+	if journal == nil {
+		return
+	}
+
+	_ = journal.Record(util.NonceJournalEntry{
+		From:        from,
+		Nonce:       nonce,
+		TxHash:      txHash,
+		RawTx:       rawTx,
+		SubmittedAt: time.Now(),
+	})
+}
+
+// reapConfirmedTx removes a confirmed transaction's entry from the journal.
+func reapConfirmedTx(journal *util.NonceJournal, from ethcmn.Address, nonce uint64) {
+	if journal == nil {
+		return
+	}
+
+	_ = journal.Remove(from, nonce)
+}
+
+// journalReapPeriod is how often journalReaperLoop polls for mined journal
+// entries. Matches TxTracker's default pollPeriod, since both exist to bound
+// how long a node round trip can lag behind reality.
+const journalReapPeriod = 15 * time.Second
+
+// startJournalReaper launches the background goroutine that keeps the
+// nonce journal from growing unbounded between restarts. It is the
+// companion to TxTracker's confirmations-aware reap for callers that use
+// ethCommitter.SendTx directly without wrapping it in a TxTracker: without
+// this, entries would otherwise only ever be cleaned up by the next
+// process restart's replay. Unlike TxTracker, this does not wait for K
+// confirmations — it only drops entries once they have a receipt, to avoid
+// reorg-safety logic duplicated from TxTracker; pair with TxTracker for
+// that guarantee. No-op if there is no journal, or the reaper is already
+// running.
+func (e *ethCommitter) startJournalReaper(ctx context.Context) {
+	if e.nonceJournal == nil || e.journalReaperCancel != nil {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	e.journalReaperCancel = cancel
+	e.journalReaperDone = make(chan struct{})
+
+	go e.journalReaperLoop(ctx)
+}
+
+// StopJournalReaper stops the background journal reaper started by
+// NewEthCommitter, if one is running, and waits for it to exit.
+func (e *ethCommitter) StopJournalReaper() {
+	if e.journalReaperCancel == nil {
+		return
+	}
+
+	e.journalReaperCancel()
+	<-e.journalReaperDone
+}
+
+func (e *ethCommitter) journalReaperLoop(ctx context.Context) {
+	defer close(e.journalReaperDone)
+
+	ticker := time.NewTicker(journalReapPeriod)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			e.reapMinedJournalEntriesOnce(ctx)
+		}
+	}
+}
+
+// reapMinedJournalEntriesOnce does a single pass over the journal's
+// outstanding entries, dropping any that already have a receipt. It runs
+// off the hot send path (see startJournalReaper), since a blocking
+// TransactionReceipt round trip per outstanding entry would otherwise
+// undermine chunk0-3's pipelining of SendTx.
+func (e *ethCommitter) reapMinedJournalEntriesOnce(ctx context.Context) {
+	for _, entry := range e.nonceJournal.Unconfirmed(e.fromAddress) {
+		if _, err := e.evmProvider.TransactionReceipt(ctx, entry.TxHash); err == nil {
+			reapConfirmedTx(e.nonceJournal, e.fromAddress, entry.Nonce)
+		}
+	}
+}
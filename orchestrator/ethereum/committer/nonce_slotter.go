@@ -0,0 +1,74 @@
+package committer
+
+import (
+	"container/heap"
+	"sync"
+)
+
+// nonceSlotter hands out monotonically increasing nonces for a single
+// address without holding a lock across the RPC round trip. Reserve only
+// ever touches in-memory state, so many SendTx calls can be in flight to the
+// node at once; a permanently failed submission returns its nonce via
+// Release so a later Reserve can fill the hole instead of the counter
+// drifting ahead of what was actually broadcast.
+type nonceSlotter struct {
+	mu    sync.Mutex
+	next  uint64
+	holes nonceHeap
+}
+
+// newNonceSlotter seeds the slotter so the next Reserve returns start.
+func newNonceSlotter(start uint64) *nonceSlotter {
+	return &nonceSlotter{next: start}
+}
+
+// Reserve pops the lowest freed nonce if one is available, otherwise
+// allocates the next never-used nonce.
+func (s *nonceSlotter) Reserve() uint64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(s.holes) > 0 {
+		return heap.Pop(&s.holes).(uint64)
+	}
+
+	n := s.next
+	s.next++
+	return n
+}
+
+// Release returns a reserved nonce to the pool of holes because the
+// submission that reserved it failed permanently (i.e. for a reason other
+// than the nonce itself being wrong) and was never accepted by the node.
+func (s *nonceSlotter) Release(nonce uint64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	heap.Push(&s.holes, nonce)
+}
+
+// Reseed discards all outstanding holes and resumes counting from start. It
+// is used after a "nonce too low/high" response to resync with the network,
+// since any reservation made under the old counter is no longer trustworthy.
+func (s *nonceSlotter) Reseed(start uint64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.next = start
+	s.holes = nil
+}
+
+// nonceHeap is a container/heap min-heap of reserved-then-released nonces.
+type nonceHeap []uint64
+
+func (h nonceHeap) Len() int            { return len(h) }
+func (h nonceHeap) Less(i, j int) bool  { return h[i] < h[j] }
+func (h nonceHeap) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *nonceHeap) Push(x interface{}) { *h = append(*h, x.(uint64)) }
+func (h *nonceHeap) Pop() interface{} {
+	old := *h
+	n := len(old)
+	x := old[n-1]
+	*h = old[:n-1]
+	return x
+}
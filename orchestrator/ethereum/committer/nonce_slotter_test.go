@@ -0,0 +1,98 @@
+package committer
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestNonceSlotterReserveIsSequential(t *testing.T) {
+	s := newNonceSlotter(5)
+
+	for i, want := range []uint64{5, 6, 7, 8} {
+		if got := s.Reserve(); got != want {
+			t.Fatalf("reserve #%d: got nonce %d, want %d", i, got, want)
+		}
+	}
+}
+
+func TestNonceSlotterReleaseFillsHole(t *testing.T) {
+	s := newNonceSlotter(0)
+
+	_ = s.Reserve() // 0
+	one := s.Reserve()
+	_ = s.Reserve() // 2
+
+	s.Release(one)
+
+	if got := s.Reserve(); got != one {
+		t.Fatalf("expected released nonce %d to be reused, got %d", one, got)
+	}
+
+	if got := s.Reserve(); got != 3 {
+		t.Fatalf("expected counter to resume at 3, got %d", got)
+	}
+}
+
+func TestNonceSlotterReleaseReturnsLowestHoleFirst(t *testing.T) {
+	s := newNonceSlotter(0)
+
+	for i := 0; i < 5; i++ {
+		s.Reserve()
+	}
+
+	s.Release(3)
+	s.Release(1)
+	s.Release(4)
+
+	for _, want := range []uint64{1, 3, 4} {
+		if got := s.Reserve(); got != want {
+			t.Fatalf("expected holes to drain lowest-first: got %d, want %d", got, want)
+		}
+	}
+}
+
+func TestNonceSlotterReseedDiscardsHoles(t *testing.T) {
+	s := newNonceSlotter(0)
+
+	s.Reserve()
+	s.Reserve()
+	s.Release(0)
+
+	s.Reseed(100)
+
+	if got := s.Reserve(); got != 100 {
+		t.Fatalf("expected reseed to discard holes and restart at 100, got %d", got)
+	}
+}
+
+func TestNonceSlotterReserveIsConcurrencySafeAndGapFree(t *testing.T) {
+	s := newNonceSlotter(0)
+
+	const goroutines = 50
+	seen := make([]uint64, goroutines)
+
+	var wg sync.WaitGroup
+	wg.Add(goroutines)
+	for i := 0; i < goroutines; i++ {
+		i := i
+		go func() {
+			defer wg.Done()
+			seen[i] = s.Reserve()
+		}()
+	}
+	wg.Wait()
+
+	dedup := make(map[uint64]bool, goroutines)
+	for _, n := range seen {
+		if dedup[n] {
+			t.Fatalf("nonce %d reserved more than once", n)
+		}
+		dedup[n] = true
+	}
+
+	for n := uint64(0); n < goroutines; n++ {
+		if !dedup[n] {
+			t.Fatalf("nonce %d was never reserved, leaving a gap", n)
+		}
+	}
+}
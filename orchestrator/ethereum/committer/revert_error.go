@@ -0,0 +1,62 @@
+package committer
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+)
+
+// RevertError is returned by SendTx when WithSimulateBeforeSend is enabled
+// and the pre-flight eth_call reverts. It lets callers (e.g. the relayer
+// loop) branch on known on-chain conditions like "batch already executed"
+// or "invalid validator set" instead of having to pattern-match an RPC error
+// string after burning gas on an on-chain revert.
+type RevertError struct {
+	// Reason is the decoded Solidity revert string, or the matched custom
+	// error name when an ABI was registered via WithRevertABI. Empty if the
+	// revert data could not be decoded.
+	Reason string
+	// Selector is the 4-byte function/error selector the revert data began
+	// with, if any.
+	Selector [4]byte
+	// Data is the raw bytes returned by the reverting call.
+	Data []byte
+}
+
+func (e *RevertError) Error() string {
+	if e.Reason != "" {
+		return fmt.Sprintf("execution reverted: %s", e.Reason)
+	}
+
+	return fmt.Sprintf("execution reverted: unknown selector 0x%x", e.Selector)
+}
+
+// decodeRevertReason attempts to turn the data returned by a reverting
+// eth_call into a RevertError. It understands the standard
+// Error(string) encoding (selector 0x08c379a0) and, when errABI is
+// non-nil, any custom Solidity error declared in it.
+func decodeRevertReason(data []byte, errABI *abi.ABI) *RevertError {
+	revertErr := &RevertError{Data: data}
+	if len(data) < 4 {
+		return revertErr
+	}
+
+	copy(revertErr.Selector[:], data[:4])
+
+	if reason, err := abi.UnpackRevert(data); err == nil {
+		revertErr.Reason = reason
+		return revertErr
+	}
+
+	if errABI != nil {
+		for name, errDef := range errABI.Errors {
+			if bytes.Equal(errDef.ID[:4], revertErr.Selector[:]) {
+				revertErr.Reason = name
+				return revertErr
+			}
+		}
+	}
+
+	return revertErr
+}
@@ -0,0 +1,80 @@
+package committer
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+var errorStringSelector = crypto.Keccak256([]byte("Error(string)"))[:4]
+
+// packRevertReason builds the standard Solidity `revert("msg")` encoding:
+// the Error(string) selector followed by the ABI-encoded string.
+func packRevertReason(t *testing.T, msg string) []byte {
+	t.Helper()
+
+	stringTy, err := abi.NewType("string", "", nil)
+	if err != nil {
+		t.Fatalf("failed to build abi type: %v", err)
+	}
+
+	packed, err := abi.Arguments{{Type: stringTy}}.Pack(msg)
+	if err != nil {
+		t.Fatalf("failed to pack revert reason: %v", err)
+	}
+
+	return append(append([]byte{}, errorStringSelector...), packed...)
+}
+
+func TestDecodeRevertReasonStandardString(t *testing.T) {
+	data := packRevertReason(t, "batch already executed")
+
+	revertErr := decodeRevertReason(data, nil)
+
+	if revertErr.Reason != "batch already executed" {
+		t.Fatalf("expected decoded reason %q, got %q", "batch already executed", revertErr.Reason)
+	}
+	if string(revertErr.Selector[:]) != string(errorStringSelector) {
+		t.Fatalf("expected selector %x, got %x", errorStringSelector, revertErr.Selector)
+	}
+}
+
+func TestDecodeRevertReasonCustomError(t *testing.T) {
+	errABIJSON := `[{"type":"error","name":"InvalidValidatorSet","inputs":[]}]`
+	errABI, err := abi.JSON(strings.NewReader(errABIJSON))
+	if err != nil {
+		t.Fatalf("failed to parse error abi: %v", err)
+	}
+
+	errDef := errABI.Errors["InvalidValidatorSet"]
+	selector := errDef.ID[:4]
+
+	revertErr := decodeRevertReason(selector, &errABI)
+
+	if revertErr.Reason != "InvalidValidatorSet" {
+		t.Fatalf("expected custom error name to be decoded, got %q", revertErr.Reason)
+	}
+}
+
+func TestDecodeRevertReasonUnknownSelector(t *testing.T) {
+	data := []byte{0xde, 0xad, 0xbe, 0xef, 0x01}
+
+	revertErr := decodeRevertReason(data, nil)
+
+	if revertErr.Reason != "" {
+		t.Fatalf("expected no reason to be decoded for an unknown selector, got %q", revertErr.Reason)
+	}
+	if len(revertErr.Data) != len(data) {
+		t.Fatalf("expected raw data to be preserved")
+	}
+}
+
+func TestDecodeRevertReasonShortData(t *testing.T) {
+	revertErr := decodeRevertReason([]byte{0x01, 0x02}, nil)
+
+	if revertErr.Reason != "" {
+		t.Fatalf("expected no reason for data shorter than a selector")
+	}
+}
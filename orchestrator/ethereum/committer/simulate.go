@@ -0,0 +1,136 @@
+package committer
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	ethcmn "github.com/ethereum/go-ethereum/common"
+)
+
+// WithSimulateBeforeSend enables a pre-flight eth_call, with the same
+// from/to/data/gas and fee fields a real submission would use, before every
+// SendTx and SendTx1559. If the call reverts, the send returns a
+// *RevertError without consuming a nonce instead of broadcasting a
+// transaction that is certain to fail on-chain.
+func WithSimulateBeforeSend(simulate bool) EVMCommitterOption {
+	return func(o *options) error {
+		o.SimulateBeforeSend = simulate
+		return nil
+	}
+}
+
+// WithRevertABI registers errABI so custom Solidity errors (as opposed to
+// the standard Error(string) revert reason) can be decoded into a
+// RevertError's Reason by their name.
+func WithRevertABI(errABI abi.ABI) EVMCommitterOption {
+	return func(o *options) error {
+		o.RevertABI = &errABI
+		return nil
+	}
+}
+
+// simulate performs the pre-flight eth_call described by WithSimulateBeforeSend
+// for a legacy transaction.
+// It returns a non-nil *RevertError if (and only if) the call reverted with
+// data we can attribute to the contract. Any other failure of the eth_call
+// itself (RPC timeout, rate limit, connection reset, ...) is logged and
+// swallowed: simulation is a best-effort optimization, and failing the real
+// send closed because the unrelated simulate call glitched would be worse
+// than just letting the send proceed and find out on-chain.
+func (e *ethCommitter) simulate(
+	ctx context.Context,
+	recipient ethcmn.Address,
+	txData []byte,
+	gasCost uint64,
+	gasPrice *big.Int,
+) *RevertError {
+	if !e.committerOpts.SimulateBeforeSend {
+		return nil
+	}
+
+	msg := ethereum.CallMsg{
+		From:     e.fromAddress,
+		To:       &recipient,
+		Gas:      gasCost,
+		GasPrice: gasPrice,
+		Data:     txData,
+	}
+
+	return e.simulateCallMsg(ctx, msg)
+}
+
+// simulate1559 is simulate's EIP-1559 counterpart, called from
+// sendTx1559Reserved with a GasFeeCap/GasTipCap CallMsg instead of a
+// GasPrice one so the dynamic-fee path gets the same pre-flight revert
+// simulation as the legacy path.
+func (e *ethCommitter) simulate1559(
+	ctx context.Context,
+	recipient ethcmn.Address,
+	txData []byte,
+	gasCost uint64,
+	tipCap *big.Int,
+	feeCap *big.Int,
+) *RevertError {
+	if !e.committerOpts.SimulateBeforeSend {
+		return nil
+	}
+
+	msg := ethereum.CallMsg{
+		From:      e.fromAddress,
+		To:        &recipient,
+		Gas:       gasCost,
+		GasFeeCap: feeCap,
+		GasTipCap: tipCap,
+		Data:      txData,
+	}
+
+	return e.simulateCallMsg(ctx, msg)
+}
+
+// simulateCallMsg is the shared implementation behind simulate and
+// simulate1559.
+func (e *ethCommitter) simulateCallMsg(ctx context.Context, msg ethereum.CallMsg) *RevertError {
+	ret, err := e.evmProvider.CallContract(ctx, msg, nil)
+	if err == nil {
+		return nil
+	}
+
+	data, ok := extractRevertData(err)
+	if !ok {
+		// not a revert we can decode (e.g. a transport error); log and let
+		// the real send proceed rather than failing closed.
+		e.logger.Err(err).Msg("pre-flight eth_call failed; skipping simulation for this send")
+		return nil
+	}
+
+	if len(data) == 0 {
+		data = ret
+	}
+
+	return decodeRevertReason(data, e.committerOpts.RevertABI)
+}
+
+// extractRevertData pulls the revert payload out of the error returned by an
+// eth_call, if the node's JSON-RPC error supports it (go-ethereum's rpc.Error
+// plus the de-facto DataError extension most clients implement).
+func extractRevertData(err error) ([]byte, bool) {
+	type dataError interface {
+		ErrorData() interface{}
+	}
+
+	de, ok := err.(dataError)
+	if !ok {
+		return nil, false
+	}
+
+	switch data := de.ErrorData().(type) {
+	case []byte:
+		return data, true
+	case string:
+		return ethcmn.FromHex(data), true
+	default:
+		return nil, false
+	}
+}
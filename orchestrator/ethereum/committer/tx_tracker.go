@@ -0,0 +1,357 @@
+package committer
+
+import (
+	"context"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	ethcmn "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/rs/zerolog"
+)
+
+// gethReplacementBump is the minimum price bump Geth's txpool enforces for a
+// replacement transaction sharing the same nonce (12.5%, expressed as a
+// numerator/denominator pair to stay in integer arithmetic).
+const (
+	gethReplacementBumpNum = 9
+	gethReplacementBumpDen = 8
+)
+
+// computeBumpedGasPrice applies the gethReplacementBumpNum/Den bump to
+// gasPrice. ok is false if maxGasPrice is set and the bumped price would
+// exceed it, in which case the caller should not resend.
+func computeBumpedGasPrice(gasPrice, maxGasPrice *big.Int) (bumped *big.Int, ok bool) {
+	bumped = new(big.Int).Mul(gasPrice, big.NewInt(gethReplacementBumpNum))
+	bumped.Div(bumped, big.NewInt(gethReplacementBumpDen))
+
+	if maxGasPrice != nil && bumped.Cmp(maxGasPrice) > 0 {
+		return bumped, false
+	}
+
+	return bumped, true
+}
+
+// isConfirmedDepth reports whether a transaction mined at receiptBlock has
+// reached confirmations block confirmations, given the chain's current
+// head.
+func isConfirmedDepth(headBlock, receiptBlock, confirmations uint64) bool {
+	return headBlock >= receiptBlock+confirmations
+}
+
+// TxTrackerMetrics exposes hooks a caller can wire up to a metrics backend.
+// Any field left nil is simply not invoked.
+type TxTrackerMetrics struct {
+	// PendingCount is called with the number of currently tracked,
+	// unconfirmed transactions every time the tracker's poll loop runs.
+	PendingCount func(n int)
+	// BumpCount is invoked once per gas-price replacement that is broadcast.
+	BumpCount func()
+	// ReplacementMined is invoked with the receipt of whichever attempt
+	// (original or bumped replacement) ends up being the one actually mined.
+	ReplacementMined func(receipt *types.Receipt)
+}
+
+// TxHandle is returned by TxTracker.SendTx and lets the caller wait for the
+// transaction (or one of its fee-bumped replacements) to reach the tracker's
+// configured confirmation depth.
+type TxHandle struct {
+	nonce uint64
+
+	mu        sync.Mutex
+	txHash    ethcmn.Hash
+	receipt   *types.Receipt
+	err       error
+	confirmed chan struct{}
+}
+
+// TxHash returns the hash of the most recently broadcast attempt (the
+// original submission, or its latest fee-bumped replacement).
+func (h *TxHandle) TxHash() ethcmn.Hash {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.txHash
+}
+
+// Confirmed blocks until the tracked transaction has reached the tracker's
+// configured confirmation depth, or ctx is canceled.
+func (h *TxHandle) Confirmed(ctx context.Context) (*types.Receipt, error) {
+	select {
+	case <-h.confirmed:
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		return h.receipt, h.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func (h *TxHandle) resolve(receipt *types.Receipt, err error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	select {
+	case <-h.confirmed:
+		// already resolved
+	default:
+		h.receipt = receipt
+		h.err = err
+		close(h.confirmed)
+	}
+}
+
+// trackedTx is the tracker's bookkeeping for one in-flight nonce.
+type trackedTx struct {
+	recipient ethcmn.Address
+	data      []byte
+	gasLimit  uint64
+	gasPrice  *big.Int
+
+	submittedAt time.Time
+	bumps       int
+
+	handle *TxHandle
+}
+
+// TxTracker resubmits transactions that remain unmined past a configured
+// deadline, bumping the gas price by gethReplacementBumpNum/Den (Geth's
+// minimum replacement bump) while reusing the same nonce. It is the "resend"
+// counterpart to ethCommitter.SendTx, modeled after the resend loop in
+// Ethermint/laconicd and the sender abstraction in taiko-client.
+type TxTracker struct {
+	logger    zerolog.Logger
+	committer *ethCommitter
+
+	pollPeriod     time.Duration
+	resendDeadline time.Duration
+	confirmations  uint64
+	maxGasPrice    *big.Int
+	metrics        TxTrackerMetrics
+
+	mu      sync.Mutex
+	pending map[uint64]*trackedTx
+
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// NewTxTracker returns a TxTracker bound to committer. resendDeadline is how
+// long a submission is given to be picked up before it is replaced with a
+// bumped-gas-price resend; confirmations is how many block confirmations a
+// receipt needs before a handle's Confirmed resolves. maxGasPrice caps how
+// far repeated bumps are allowed to escalate, nil meaning uncapped.
+func NewTxTracker(
+	committer *ethCommitter,
+	resendDeadline time.Duration,
+	confirmations uint64,
+	maxGasPrice *big.Int,
+) *TxTracker {
+	return &TxTracker{
+		logger:         committer.logger.With().Str("module", "txTracker").Logger(),
+		committer:      committer,
+		pollPeriod:     15 * time.Second,
+		resendDeadline: resendDeadline,
+		confirmations:  confirmations,
+		maxGasPrice:    maxGasPrice,
+		pending:        make(map[uint64]*trackedTx),
+		done:           make(chan struct{}),
+	}
+}
+
+// WithMetrics installs the given metrics hooks. Not safe to call once Start
+// has been invoked.
+func (t *TxTracker) WithMetrics(metrics TxTrackerMetrics) *TxTracker {
+	t.metrics = metrics
+	return t
+}
+
+// Start launches the background goroutine that polls pending transactions
+// and resends those stuck past their deadline. It is a no-op if already
+// started.
+func (t *TxTracker) Start(ctx context.Context) {
+	if t.cancel != nil {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	t.cancel = cancel
+
+	go t.pollLoop(ctx)
+}
+
+// Stop cancels the background poll loop and waits for it to exit.
+func (t *TxTracker) Stop() {
+	if t.cancel == nil {
+		return
+	}
+
+	t.cancel()
+	<-t.done
+}
+
+// SendTx broadcasts a transaction via the underlying committer and starts
+// tracking it for resend. Unlike ethCommitter.SendTx, it returns immediately
+// with a handle the caller can await confirmation on; resends happen
+// transparently in the background.
+func (t *TxTracker) SendTx(
+	ctx context.Context,
+	recipient ethcmn.Address,
+	txData []byte,
+	gasCost uint64,
+	gasPrice *big.Int,
+) (*TxHandle, error) {
+	nonce, txHash, err := t.committer.sendTxReserved(ctx, recipient, txData, gasCost, gasPrice)
+	if err != nil {
+		return nil, err
+	}
+
+	handle := &TxHandle{
+		nonce:     nonce,
+		txHash:    txHash,
+		confirmed: make(chan struct{}),
+	}
+
+	t.mu.Lock()
+	t.pending[nonce] = &trackedTx{
+		recipient:   recipient,
+		data:        txData,
+		gasLimit:    gasCost,
+		gasPrice:    gasPrice,
+		submittedAt: time.Now(),
+		handle:      handle,
+	}
+	t.mu.Unlock()
+
+	return handle, nil
+}
+
+func (t *TxTracker) pollLoop(ctx context.Context) {
+	defer close(t.done)
+
+	ticker := time.NewTicker(t.pollPeriod)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			t.pollOnce(ctx)
+		}
+	}
+}
+
+func (t *TxTracker) pollOnce(ctx context.Context) {
+	t.mu.Lock()
+	txs := make([]*trackedTx, 0, len(t.pending))
+	for _, tx := range t.pending {
+		txs = append(txs, tx)
+	}
+	t.mu.Unlock()
+
+	if t.metrics.PendingCount != nil {
+		t.metrics.PendingCount(len(txs))
+	}
+
+	for _, tx := range txs {
+		t.checkOrResend(ctx, tx)
+	}
+}
+
+func (t *TxTracker) checkOrResend(ctx context.Context, tx *trackedTx) {
+	txHash := tx.handle.TxHash()
+
+	receipt, err := t.committer.evmProvider.TransactionReceipt(ctx, txHash)
+	if err == nil && receipt != nil {
+		t.confirmIfDeep(ctx, tx, receipt)
+		return
+	}
+
+	if time.Since(tx.submittedAt) < t.resendDeadline {
+		return
+	}
+
+	t.resend(ctx, tx)
+}
+
+func (t *TxTracker) confirmIfDeep(ctx context.Context, tx *trackedTx, receipt *types.Receipt) {
+	header, err := t.committer.evmProvider.HeaderByNumber(ctx, nil)
+	if err != nil {
+		t.logger.Err(err).Msg("unable to fetch latest header to check confirmations")
+		return
+	}
+
+	if !isConfirmedDepth(header.Number.Uint64(), receipt.BlockNumber.Uint64(), t.confirmations) {
+		return
+	}
+
+	t.mu.Lock()
+	delete(t.pending, tx.handle.nonce)
+	t.mu.Unlock()
+
+	reapConfirmedTx(t.committer.nonceJournal, t.committer.fromAddress, tx.handle.nonce)
+
+	if t.metrics.ReplacementMined != nil {
+		t.metrics.ReplacementMined(receipt)
+	}
+
+	tx.handle.resolve(receipt, nil)
+}
+
+func (t *TxTracker) resend(ctx context.Context, tx *trackedTx) {
+	bumped, ok := computeBumpedGasPrice(tx.gasPrice, t.maxGasPrice)
+	if !ok {
+		t.logger.Warn().
+			Str("bumped_gas_price", bumped.String()).
+			Str("max_gas_price", t.maxGasPrice.String()).
+			Msg("refusing to bump gas price past configured cap")
+		return
+	}
+
+	opts := &bind.TransactOpts{
+		From:     t.committer.fromAddress,
+		Signer:   t.committer.fromSigner,
+		GasPrice: bumped,
+		GasLimit: tx.gasLimit,
+		Nonce:    new(big.Int).SetUint64(tx.handle.nonce),
+		Context:  ctx,
+	}
+
+	signedTx, err := opts.Signer(opts.From, types.NewTransaction(
+		opts.Nonce.Uint64(), tx.recipient, nil, opts.GasLimit, opts.GasPrice, tx.data,
+	))
+	if err != nil {
+		t.logger.Err(err).Msg("failed to sign bumped replacement transaction")
+		return
+	}
+
+	txHash, err := t.committer.evmProvider.SendTransactionWithRet(ctx, signedTx)
+	if err != nil {
+		t.logger.Err(err).Msg("failed to broadcast bumped replacement transaction")
+		return
+	}
+
+	if rawTx, encErr := signedTx.MarshalBinary(); encErr == nil {
+		recordSentTx(t.committer.nonceJournal, t.committer.fromAddress, tx.handle.nonce, txHash, rawTx)
+	}
+
+	tx.gasPrice = bumped
+	tx.submittedAt = time.Now()
+	tx.bumps++
+
+	tx.handle.mu.Lock()
+	tx.handle.txHash = txHash
+	tx.handle.mu.Unlock()
+
+	if t.metrics.BumpCount != nil {
+		t.metrics.BumpCount()
+	}
+
+	t.logger.Info().
+		Str("tx_hash", txHash.Hex()).
+		Uint64("nonce", tx.handle.nonce).
+		Int("bumps", tx.bumps).
+		Str("gas_price", bumped.String()).
+		Msg("resent stuck transaction with bumped gas price")
+}
@@ -0,0 +1,89 @@
+package committer
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+func TestComputeBumpedGasPriceAppliesGethMinimumBump(t *testing.T) {
+	bumped, ok := computeBumpedGasPrice(big.NewInt(1000), nil)
+	if !ok {
+		t.Fatal("expected bump to be allowed when maxGasPrice is unset")
+	}
+
+	// 1000 * 9/8 = 1125
+	if want := big.NewInt(1125); bumped.Cmp(want) != 0 {
+		t.Fatalf("expected bumped gas price %s, got %s", want, bumped)
+	}
+}
+
+func TestComputeBumpedGasPriceRefusesPastCap(t *testing.T) {
+	_, ok := computeBumpedGasPrice(big.NewInt(1000), big.NewInt(1100))
+	if ok {
+		t.Fatal("expected bump past maxGasPrice to be refused")
+	}
+}
+
+func TestComputeBumpedGasPriceAllowsAtCap(t *testing.T) {
+	bumped, ok := computeBumpedGasPrice(big.NewInt(1000), big.NewInt(1125))
+	if !ok {
+		t.Fatal("expected bump exactly at the cap to be allowed")
+	}
+	if bumped.Cmp(big.NewInt(1125)) != 0 {
+		t.Fatalf("expected bumped gas price 1125, got %s", bumped)
+	}
+}
+
+func TestIsConfirmedDepth(t *testing.T) {
+	cases := []struct {
+		name                    string
+		head, receipt, confirms uint64
+		want                    bool
+	}{
+		{"not enough confirmations yet", 105, 100, 10, false},
+		{"exactly at the confirmation depth", 110, 100, 10, true},
+		{"well past the confirmation depth", 200, 100, 10, true},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := isConfirmedDepth(c.head, c.receipt, c.confirms); got != c.want {
+				t.Fatalf("isConfirmedDepth(%d, %d, %d): got %v, want %v", c.head, c.receipt, c.confirms, got, c.want)
+			}
+		})
+	}
+}
+
+func TestTxHandleResolveFiresOnce(t *testing.T) {
+	handle := &TxHandle{confirmed: make(chan struct{})}
+
+	receipt := &types.Receipt{BlockNumber: big.NewInt(1)}
+	handle.resolve(receipt, nil)
+	handle.resolve(&types.Receipt{BlockNumber: big.NewInt(2)}, context.Canceled)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	got, err := handle.Confirmed(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.BlockNumber.Cmp(receipt.BlockNumber) != 0 {
+		t.Fatalf("expected the first resolve to win, got block %s", got.BlockNumber)
+	}
+}
+
+func TestTxHandleConfirmedBlocksUntilResolved(t *testing.T) {
+	handle := &TxHandle{confirmed: make(chan struct{})}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	if _, err := handle.Confirmed(ctx); err == nil {
+		t.Fatal("expected Confirmed to time out before resolve is called")
+	}
+}
@@ -0,0 +1,155 @@
+package util
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	ethcmn "github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+)
+
+// NonceJournalEntry records everything needed to recognize and, if
+// necessary, rebroadcast a transaction that SendTx submitted: not just the
+// (from, nonce, txHash, submittedAt) tuple needed to look the tx up again,
+// but also its raw signed bytes so it can be resent verbatim if the node
+// that originally accepted it no longer has it in its mempool.
+type NonceJournalEntry struct {
+	From        ethcmn.Address `json:"from"`
+	Nonce       uint64         `json:"nonce"`
+	TxHash      ethcmn.Hash    `json:"tx_hash"`
+	RawTx       []byte         `json:"raw_tx"`
+	SubmittedAt time.Time      `json:"submitted_at"`
+	// Removed marks a tombstone record, written by Remove. The journal itself
+	// has no confirmation depth: it removes an entry whenever Remove is
+	// called, however many confirmations the tx has at that point. The
+	// journal file is append-only, so a removal is itself just another line
+	// rather than an in-place edit.
+	Removed bool `json:"removed,omitempty"`
+}
+
+type journalKey struct {
+	from  ethcmn.Address
+	nonce uint64
+}
+
+// NonceJournal is an on-disk append-only log of in-flight transactions,
+// keyed by (from, nonce). It exists so that an orchestrator restart does not
+// lose track of transactions it broadcast but can no longer see in the
+// mempool, a footgun status-go's own move away from a purely in-memory
+// nonce cache was meant to fix.
+type NonceJournal struct {
+	mu      sync.Mutex
+	file    *os.File
+	entries map[journalKey]NonceJournalEntry
+}
+
+// OpenNonceJournal opens (creating if necessary) the journal file at path
+// and replays it into memory, applying tombstones in file order so the
+// returned journal reflects only still-unconfirmed entries.
+func OpenNonceJournal(path string) (*NonceJournal, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR|os.O_APPEND, 0o600)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to open nonce journal at %s", path)
+	}
+
+	entries := make(map[journalKey]NonceJournalEntry)
+
+	scanner := bufio.NewScanner(f)
+	// journal lines embed raw transaction bytes, so allow a generous line size.
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var entry NonceJournalEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			continue // tolerate a torn last line from a crash mid-write
+		}
+
+		key := journalKey{from: entry.From, nonce: entry.Nonce}
+		if entry.Removed {
+			delete(entries, key)
+			continue
+		}
+
+		entries[key] = entry
+	}
+
+	if err := scanner.Err(); err != nil {
+		f.Close()
+		return nil, errors.Wrap(err, "failed to read nonce journal")
+	}
+
+	return &NonceJournal{file: f, entries: entries}, nil
+}
+
+// Record appends a new in-flight transaction to the journal.
+func (j *NonceJournal) Record(entry NonceJournalEntry) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if err := j.appendLine(entry); err != nil {
+		return err
+	}
+
+	j.entries[journalKey{from: entry.From, nonce: entry.Nonce}] = entry
+	return nil
+}
+
+// Remove marks the transaction at (from, nonce) as confirmed by appending a
+// tombstone record.
+func (j *NonceJournal) Remove(from ethcmn.Address, nonce uint64) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	key := journalKey{from: from, nonce: nonce}
+	if _, ok := j.entries[key]; !ok {
+		return nil
+	}
+
+	if err := j.appendLine(NonceJournalEntry{From: from, Nonce: nonce, Removed: true}); err != nil {
+		return err
+	}
+
+	delete(j.entries, key)
+	return nil
+}
+
+// Unconfirmed returns the still-outstanding journal entries for from,
+// ordered by nonce.
+func (j *NonceJournal) Unconfirmed(from ethcmn.Address) []NonceJournalEntry {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	out := make([]NonceJournalEntry, 0, len(j.entries))
+	for key, entry := range j.entries {
+		if key.from == from {
+			out = append(out, entry)
+		}
+	}
+
+	sort.Slice(out, func(i, k int) bool { return out[i].Nonce < out[k].Nonce })
+	return out
+}
+
+// Close releases the underlying file handle.
+func (j *NonceJournal) Close() error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.file.Close()
+}
+
+func (j *NonceJournal) appendLine(entry NonceJournalEntry) error {
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal nonce journal entry")
+	}
+
+	line = append(line, '\n')
+	if _, err := j.file.Write(line); err != nil {
+		return errors.Wrap(err, "failed to append to nonce journal")
+	}
+
+	return j.file.Sync()
+}
@@ -0,0 +1,121 @@
+package util
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	ethcmn "github.com/ethereum/go-ethereum/common"
+)
+
+var testAddr = ethcmn.HexToAddress("0x1111111111111111111111111111111111111111")
+
+func TestNonceJournalReplaysAppendOnlyRecords(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "journal")
+
+	journal, err := OpenNonceJournal(path)
+	if err != nil {
+		t.Fatalf("failed to open journal: %v", err)
+	}
+
+	if err := journal.Record(NonceJournalEntry{From: testAddr, Nonce: 1, RawTx: []byte("tx1")}); err != nil {
+		t.Fatalf("failed to record entry: %v", err)
+	}
+	if err := journal.Record(NonceJournalEntry{From: testAddr, Nonce: 2, RawTx: []byte("tx2")}); err != nil {
+		t.Fatalf("failed to record entry: %v", err)
+	}
+	if err := journal.Close(); err != nil {
+		t.Fatalf("failed to close journal: %v", err)
+	}
+
+	reopened, err := OpenNonceJournal(path)
+	if err != nil {
+		t.Fatalf("failed to reopen journal: %v", err)
+	}
+	defer reopened.Close()
+
+	unconfirmed := reopened.Unconfirmed(testAddr)
+	if len(unconfirmed) != 2 {
+		t.Fatalf("expected 2 unconfirmed entries after reopen, got %d", len(unconfirmed))
+	}
+	if unconfirmed[0].Nonce != 1 || unconfirmed[1].Nonce != 2 {
+		t.Fatalf("expected entries ordered by nonce, got %+v", unconfirmed)
+	}
+}
+
+func TestNonceJournalTombstoneAppliedInFileOrder(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "journal")
+
+	journal, err := OpenNonceJournal(path)
+	if err != nil {
+		t.Fatalf("failed to open journal: %v", err)
+	}
+
+	if err := journal.Record(NonceJournalEntry{From: testAddr, Nonce: 1, RawTx: []byte("tx1")}); err != nil {
+		t.Fatalf("failed to record entry: %v", err)
+	}
+	if err := journal.Remove(testAddr, 1); err != nil {
+		t.Fatalf("failed to remove entry: %v", err)
+	}
+	// Re-record the same nonce after the tombstone; this entry must survive
+	// the tombstone that preceded it in the file, not get removed by it.
+	if err := journal.Record(NonceJournalEntry{From: testAddr, Nonce: 1, RawTx: []byte("tx1-retry")}); err != nil {
+		t.Fatalf("failed to re-record entry: %v", err)
+	}
+	if err := journal.Close(); err != nil {
+		t.Fatalf("failed to close journal: %v", err)
+	}
+
+	reopened, err := OpenNonceJournal(path)
+	if err != nil {
+		t.Fatalf("failed to reopen journal: %v", err)
+	}
+	defer reopened.Close()
+
+	unconfirmed := reopened.Unconfirmed(testAddr)
+	if len(unconfirmed) != 1 {
+		t.Fatalf("expected 1 unconfirmed entry after reopen, got %d", len(unconfirmed))
+	}
+	if string(unconfirmed[0].RawTx) != "tx1-retry" {
+		t.Fatalf("expected the re-recorded entry to win, got %q", unconfirmed[0].RawTx)
+	}
+}
+
+func TestNonceJournalToleratesTornLastLine(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "journal")
+
+	journal, err := OpenNonceJournal(path)
+	if err != nil {
+		t.Fatalf("failed to open journal: %v", err)
+	}
+	if err := journal.Record(NonceJournalEntry{From: testAddr, Nonce: 1, RawTx: []byte("tx1")}); err != nil {
+		t.Fatalf("failed to record entry: %v", err)
+	}
+	if err := journal.Close(); err != nil {
+		t.Fatalf("failed to close journal: %v", err)
+	}
+
+	// Simulate a crash mid-write: append a truncated JSON line with no
+	// trailing newline, as os.OpenFile(O_APPEND) would leave behind.
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		t.Fatalf("failed to reopen journal file for torn write: %v", err)
+	}
+	if _, err := f.WriteString(`{"from":"0x1111","nonce":2,"tx_h`); err != nil {
+		t.Fatalf("failed to append torn line: %v", err)
+	}
+	if err := f.Close(); err != nil {
+		t.Fatalf("failed to close torn write: %v", err)
+	}
+
+	reopened, err := OpenNonceJournal(path)
+	if err != nil {
+		t.Fatalf("expected a torn last line to be tolerated, got error: %v", err)
+	}
+	defer reopened.Close()
+
+	unconfirmed := reopened.Unconfirmed(testAddr)
+	if len(unconfirmed) != 1 || unconfirmed[0].Nonce != 1 {
+		t.Fatalf("expected the torn line to be skipped and the prior entry kept, got %+v", unconfirmed)
+	}
+}